@@ -4,11 +4,17 @@ import (
 	"context"
 	"os"
 	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/istratem/gotestsum/pkg/gotestsum"
+	"github.com/istratem/gotestsum/pkg/history"
+	"github.com/istratem/gotestsum/pkg/logging"
 	"github.com/istratem/gotestsum/pkg/operator"
 	"github.com/istratem/gotestsum/pkg/options"
+	"github.com/istratem/gotestsum/pkg/policy"
+	"github.com/istratem/gotestsum/pkg/report"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"gotest.tools/gotestsum/testjson"
@@ -36,20 +42,27 @@ func main() {
 		os.Exit(1)
 	}
 	opts.Args = flags.Args()
+	applySlug(opts)
 	setupLogging(opts)
+
+	logger, err := logging.NewLogger(opts.LogBackend, opts.LogFormat)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
 	if opts.Version {
 		log.Printf("gotestmng version %s\n", version)
 		os.Exit(0)
 	}
-	log.Println(opts)
+	logger.Debug("parsed options", logging.Fields{"opts": opts})
 	if opts.Post == true {
 		if opts.GitUnAuth == true {
-			log.Println(os.Args)
-			log.Error("can not post issues without authentication")
+			logger.Error("can not post issues without authentication", logging.Fields{"args": os.Args})
 			os.Exit(1)
 		}
 	}
-	err := run(opts)
+	err = run(opts, logger)
 	switch err.(type) {
 	case nil:
 	case *exec.ExitError:
@@ -104,27 +117,57 @@ Formats:
 	flags.Var(opts.JunitTestCaseClassnameFormat, "junitfile-testcase-classname", "format the testcase classname field as: "+options.JunitFieldFormatValues)
 	flags.BoolVar(&opts.Version, "version", false, "show version and exit")
 	flags.BoolVar(&opts.GitUnAuth, "unauth", false, "use unauthenticated git operator")
+	flags.StringVar(&opts.Tracker, "tracker", "github", "issue tracker backend: github, gitlab, gitea, jira")
+	flags.StringVar(&opts.TrackerURL, "tracker-url", "", "base url of a self-hosted tracker instance")
+	flags.StringVar(&opts.CredentialSource, "credential-source", "env", "where to read the tracker token from: env, file, git-credential")
+	flags.StringVar(&opts.CredentialFile, "credential-file", "", "path to read the tracker token from, when --credential-source=file")
+	flags.StringVar(&opts.Branch, "branch", "", "override the git branch auto-detected from the CI environment")
+	flags.StringVar(&opts.SHA, "sha", "", "override the commit sha auto-detected from the CI environment")
+	flags.StringVar(&opts.PR, "pr", "", "override the pull request number auto-detected from the CI environment")
+	flags.StringVar(&opts.BuildURL, "build-url", "", "override the build url auto-detected from the CI environment")
+	flags.StringVar(&opts.CIName, "ci-name", "", "override the CI provider name auto-detected from the CI environment")
+	flags.StringVar(&opts.Slug, "slug", "", "owner/repo slug, used to fill --owner and --repo when they are unset")
+	flags.StringVar(&opts.PolicyDir, "policy-dir", "", "directory of .rego policies controlling which failures open issues; unset disables the policy layer")
+	flags.StringSliceVar(&opts.ReportFormat, "report-format", nil, "write a report in these formats: json, csv, html, sarif")
+	flags.StringVar(&opts.ReportDir, "report-dir", ".", "directory reports from --report-format are written to")
+	flags.StringVar(&opts.HistoryFile, "history-file", "", "file to persist per-test run history in, for flakiness detection; unset disables history tracking")
+	flags.StringVar(&opts.HistoryRemoteURL, "history-remote-url", "", "base url of a remote object store to persist history in instead of --history-file, for sharing history across CI runners that don't share a filesystem")
+	flags.IntVar(&opts.HistoryWindow, "history-window", 20, "number of past runs to keep per test")
+	flags.Float64Var(&opts.FlakyThreshold, "flaky-threshold", 0.2, "fail ratio, over the history window, at or above which a test is classified as flaky")
+	flags.StringVar(&opts.QuarantineFile, "quarantine-file", "", "file to write the names of flaky tests to, for `go test -skip` to consume")
+	flags.StringVar(&opts.LogBackend, "log-backend", "logrus", "structured logging backend: logrus, zap, slog")
+	flags.StringVar(&opts.LogFormat, "log-format", "text", "structured log output format: text, json")
 	return flags, &opts
 }
 
-func run(opts *options.Options) error {
-	err := gotestsum.GoTestSum(opts, log.StandardLogger())
+func run(opts *options.Options, logger logging.Logger) error {
+	err := gotestsum.GoTestSum(opts, logger)
 	switch e := err.(type) {
 	case *exec.ExitError:
 		if e.ExitCode() != 1 {
 			return err
 		}
 	}
-	var junitOperator operator.JUnitOperator
+	junitOperator := operator.NewJUnitOperator(logger)
 	failedTests := junitOperator.GetFailedTests(opts)
-	var gitOperator *operator.GitOperator
-	if opts.GitUnAuth {
-		gitOperator = operator.NewUnauthenticatedGitOperator(opts.Owner, opts.Repo, context.Background())
+	ciCtx := operator.DetectCI(opts)
+	for i, t := range failedTests {
+		failedTests[i] = t.WithCI(ciCtx)
+	}
 
-	} else {
-		gitOperator = operator.NewGitOperator(opts.Owner, opts.Repo, opts.Token, context.Background())
+	var historyTracker *history.Tracker
+	if opts.HistoryFile != "" || opts.HistoryRemoteURL != "" {
+		historyTracker, err = recordHistory(opts, junitOperator, logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	tracker, err := operator.NewTracker(opts, context.Background(), logger)
+	if err != nil {
+		return err
 	}
-	knownIssues, err := gitOperator.GetTestIssues()
+	knownIssues, err := tracker.GetTestIssues()
 	if err != nil {
 		return err
 	}
@@ -143,24 +186,64 @@ func run(opts *options.Options) error {
 			solvedIssues = append(solvedIssues, *t)
 		}
 	}
+
+	if historyTracker != nil {
+		for i, t := range newIssues {
+			key := t.Key()
+			newIssues[i].Classification = string(historyTracker.Classify(key))
+			newIssues[i].Labels = append(newIssues[i].Labels, "history:"+newIssues[i].Classification)
+			if h, ok := historyTracker.History(key); ok && len(h.Outcomes) > 0 {
+				newIssues[i].FirstSeen = h.Outcomes[0].Time
+				newIssues[i].LastSeen = h.Outcomes[len(h.Outcomes)-1].Time
+			}
+		}
+		if opts.QuarantineFile != "" {
+			if err := writeQuarantine(opts, newIssues); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.PolicyDir != "" {
+		newIssues, err = applyPolicy(opts, newIssues, historyTracker, logger)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, t := range newIssues {
-		log.Printf("NEW Failed Test found : %v\n", t)
+		logger.Info("new failed test found", logging.Fields{"test": t.Title, "package": t.Package})
 	}
 
 	if opts.Post {
 		for _, i := range newIssues {
-			err = gitOperator.PostNewIssue(&i)
+			err = tracker.PostNewIssue(&i)
 			if err != nil {
 				return err
 			}
-			log.Println("New issue created on git", i)
+			logger.Info("new issue created", logging.Fields{"tracker": opts.Tracker, "test": i.Title, "issue_url": i.IssueURL})
 		}
 		for _, i := range solvedIssues {
-			err = gitOperator.CloseSolvedIssue(&i)
+			err = tracker.CloseSolvedIssue(&i)
 			if err != nil {
 				return err
 			}
-			log.Println("Issue closed on git", i)
+			logger.Info("issue closed", logging.Fields{"tracker": opts.Tracker, "test": i.Title})
+		}
+	}
+
+	if len(opts.ReportFormat) > 0 {
+		all := make([]operator.FailedTest, len(failedTests))
+		for i, t := range failedTests {
+			all[i] = *t
+		}
+		err = report.EmitAll(opts.ReportDir, opts.ReportFormat, report.Report{
+			NewIssues:    newIssues,
+			SolvedIssues: solvedIssues,
+			FailedTests:  all,
+		})
+		if err != nil {
+			return err
 		}
 	}
 
@@ -171,6 +254,25 @@ func run(opts *options.Options) error {
 	return nil
 }
 
+// applySlug fills in --owner and --repo from --slug (an "owner/repo"
+// string, as exposed by several CI providers) when they were not set
+// explicitly.
+func applySlug(opts *options.Options) {
+	if opts.Slug == "" || (opts.Owner != "" && opts.Repo != "") {
+		return
+	}
+	parts := strings.SplitN(opts.Slug, "/", 2)
+	if len(parts) != 2 {
+		return
+	}
+	if opts.Owner == "" {
+		opts.Owner = parts[0]
+	}
+	if opts.Repo == "" {
+		opts.Repo = parts[1]
+	}
+}
+
 func lookEnvWithDefault(key, defValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -179,9 +281,104 @@ func lookEnvWithDefault(key, defValue string) string {
 	}
 }
 
+// applyPolicy runs every candidate new issue through the configured
+// Rego policies, dropping the ones a policy says shouldn't be opened and
+// attaching the labels/severity a policy assigns to the rest.
+func applyPolicy(opts *options.Options, candidates []operator.FailedTest, historyTracker *history.Tracker, logger logging.Logger) ([]operator.FailedTest, error) {
+	ctx := context.Background()
+	engine, err := policy.Load(ctx, opts.PolicyDir, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []operator.FailedTest
+	for _, t := range candidates {
+		var consecutiveFailures int
+		if historyTracker != nil {
+			consecutiveFailures = historyTracker.ConsecutiveFailures(t.Key())
+		}
+		action, err := engine.Evaluate(ctx, policy.Input{
+			Test: policy.Test{
+				Name:     t.Title,
+				Package:  t.Package,
+				Output:   t.Output,
+				Duration: t.Duration,
+			},
+			CI: policy.CI{
+				Branch:       t.CI.Branch,
+				PR:           t.CI.PR,
+				ChangedFiles: t.CI.ChangedFiles,
+			},
+			History: policy.History{ConsecutiveFailures: consecutiveFailures},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !action.ShouldOpen {
+			continue
+		}
+		t.Labels = append(t.Labels, action.Labels...)
+		t.Severity = action.Severity
+		if t.Severity != "" {
+			t.Labels = append(t.Labels, "severity:"+t.Severity)
+		}
+		kept = append(kept, t)
+	}
+	return kept, nil
+}
+
+// historyRemoteObjectKey is the object name gotestsum's run history is
+// stored under when --history-remote-url is set.
+const historyRemoteObjectKey = "gotestsum-history.json"
+
+// historyStore picks the history.Store --history-remote-url and
+// --history-file select: a remote object store takes precedence when
+// both are set, since it's the one meant to be shared across runners.
+func historyStore(opts *options.Options) history.Store {
+	if opts.HistoryRemoteURL != "" {
+		return history.RemoteStore{
+			Client: history.NewHTTPObjectStore(opts.HistoryRemoteURL),
+			Key:    historyRemoteObjectKey,
+		}
+	}
+	return history.JSONFileStore{Path: opts.HistoryFile}
+}
+
+// recordHistory feeds every test outcome from this run into the
+// persisted history store and returns the tracker, so callers can
+// classify the currently failing tests against it.
+func recordHistory(opts *options.Options, junitOperator operator.JUnitOperator, logger logging.Logger) (*history.Tracker, error) {
+	tracker := history.NewTracker(historyStore(opts), opts.HistoryWindow, opts.FlakyThreshold, logger)
+	if err := tracker.Load(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for key, passed := range junitOperator.GetAllOutcomes(opts) {
+		tracker.Record(key, passed, now)
+	}
+
+	if err := tracker.Save(); err != nil {
+		return nil, err
+	}
+	return tracker, nil
+}
+
+// writeQuarantine writes the names of the newly failing tests that
+// history classifies as flaky to opts.QuarantineFile.
+func writeQuarantine(opts *options.Options, newIssues []operator.FailedTest) error {
+	var flaky []string
+	for _, t := range newIssues {
+		if t.Classification == string(history.Flaky) {
+			flaky = append(flaky, t.Title)
+		}
+	}
+	return history.WriteQuarantineFile(opts.QuarantineFile, flaky)
+}
+
 func contains(s []*operator.FailedTest, e *operator.FailedTest) bool {
 	for _, a := range s {
-		if a.Title == e.Title /*&& a.Issues == e.Issues*/ {
+		if a.Title == e.Title && a.CI.Branch == e.CI.Branch {
 			return true
 		}
 	}
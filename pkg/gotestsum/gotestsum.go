@@ -0,0 +1,69 @@
+// Package gotestsum runs `go test -json` (or a raw command, when
+// configured) and streams the result through gotest.tools/gotestsum's
+// testjson scanner, printing the events in the format selected via
+// options.Options. It does not write the json or junit files named by
+// options.Options.
+package gotestsum
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/istratem/gotestsum/pkg/logging"
+	"github.com/istratem/gotestsum/pkg/options"
+	"gotest.tools/gotestsum/testjson"
+)
+
+// GoTestSum builds the go test command described by opts, runs it, and
+// scans its JSON output, printing it in opts.Format. opts.JsonFile and
+// opts.JunitFile are not written.
+func GoTestSum(opts *options.Options, logger logging.Logger) error {
+	args := opts.Args
+	if !opts.RawCommand {
+		args = append([]string{"test", "-json"}, args...)
+	}
+
+	logger.Info("running go test", logging.Fields{"args": args})
+
+	cmd := exec.Command("go", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	exec := testjson.ScanConfig{
+		Stdout:  stdout,
+		Handler: &eventHandler{formatter: testjson.NewEventFormatter(os.Stdout, opts.Format, testjson.FormatOptions{}), logger: logger},
+	}
+	_, scanErr := testjson.ScanTestOutput(exec)
+
+	waitErr := cmd.Wait()
+	if scanErr != nil {
+		logger.Error("scanning go test output failed", logging.Fields{"error": scanErr.Error()})
+		return scanErr
+	}
+	return waitErr
+}
+
+// eventHandler adapts a testjson.EventFormatter, which only knows how to
+// format a single TestEvent, into the testjson.EventHandler that
+// ScanConfig.Handler requires, routing stderr lines through logger
+// instead of discarding them.
+type eventHandler struct {
+	formatter testjson.EventFormatter
+	logger    logging.Logger
+}
+
+func (h *eventHandler) Event(event testjson.TestEvent, execution *testjson.Execution) error {
+	return h.formatter.Format(event, execution)
+}
+
+func (h *eventHandler) Err(text string) error {
+	h.logger.Error(text, nil)
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package history persists the last N runs' pass/fail outcome for every
+// test, keyed by "package/test", so a single failure can be told apart
+// from a flaky test or a consistently broken one.
+package history
+
+import (
+	"time"
+
+	"github.com/istratem/gotestsum/pkg/logging"
+)
+
+// Classification is the verdict history.Tracker reaches for a currently
+// failing test.
+type Classification string
+
+const (
+	// NewlyFailing means the test has no, or very little, failure
+	// history: this looks like a fresh regression.
+	NewlyFailing Classification = "newly_failing"
+	// Flaky means the test alternates between passing and failing often
+	// enough to clear FlakyThreshold.
+	Flaky Classification = "flaky"
+	// ConsistentlyFailing means the test has failed on every run in the
+	// retained window.
+	ConsistentlyFailing Classification = "consistently_failing"
+)
+
+// Outcome is a single recorded run of a test.
+type Outcome struct {
+	Time   time.Time `json:"time"`
+	Passed bool      `json:"passed"`
+}
+
+// TestHistory is the recent outcomes of a single test, most recent last.
+type TestHistory struct {
+	Outcomes []Outcome `json:"outcomes"`
+}
+
+// ConsecutiveFailures counts the failures at the tail of Outcomes.
+func (h *TestHistory) ConsecutiveFailures() int {
+	n := 0
+	for i := len(h.Outcomes) - 1; i >= 0; i-- {
+		if h.Outcomes[i].Passed {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// FailRatio is the fraction of recorded runs that failed.
+func (h *TestHistory) FailRatio() float64 {
+	if len(h.Outcomes) == 0 {
+		return 0
+	}
+	failed := 0
+	for _, o := range h.Outcomes {
+		if !o.Passed {
+			failed++
+		}
+	}
+	return float64(failed) / float64(len(h.Outcomes))
+}
+
+// Store persists the per-test history across runs.
+type Store interface {
+	Load() (map[string]*TestHistory, error)
+	Save(map[string]*TestHistory) error
+}
+
+// Tracker classifies a run's failures against the persisted history,
+// recording the run's own outcomes as it goes.
+type Tracker struct {
+	store          Store
+	window         int
+	flakyThreshold float64
+	logger         logging.Logger
+
+	data map[string]*TestHistory
+}
+
+// NewTracker builds a Tracker that keeps at most window outcomes per
+// test and classifies a test as Flaky once its FailRatio clears
+// flakyThreshold.
+func NewTracker(store Store, window int, flakyThreshold float64, logger logging.Logger) *Tracker {
+	return &Tracker{store: store, window: window, flakyThreshold: flakyThreshold, logger: logger}
+}
+
+// Load reads the persisted history from the store.
+func (t *Tracker) Load() error {
+	data, err := t.store.Load()
+	if err != nil {
+		return err
+	}
+	t.data = data
+	t.logger.Debug("history loaded", logging.Fields{"tests": len(data)})
+	return nil
+}
+
+// Save persists the tracker's current history.
+func (t *Tracker) Save() error {
+	if err := t.store.Save(t.data); err != nil {
+		return err
+	}
+	t.logger.Debug("history saved", logging.Fields{"tests": len(t.data)})
+	return nil
+}
+
+// Record appends this run's outcome for key, trimming to the configured
+// window.
+func (t *Tracker) Record(key string, passed bool, at time.Time) {
+	if t.data == nil {
+		t.data = map[string]*TestHistory{}
+	}
+	h, ok := t.data[key]
+	if !ok {
+		h = &TestHistory{}
+		t.data[key] = h
+	}
+	h.Outcomes = append(h.Outcomes, Outcome{Time: at, Passed: passed})
+	if len(h.Outcomes) > t.window {
+		h.Outcomes = h.Outcomes[len(h.Outcomes)-t.window:]
+	}
+}
+
+// History returns the recorded outcomes for key, and whether any are on
+// file, so callers can derive things like a test's first/last seen time.
+func (t *Tracker) History(key string) (*TestHistory, bool) {
+	h, ok := t.data[key]
+	return h, ok
+}
+
+// ConsecutiveFailures returns key's current consecutive-failure streak.
+func (t *Tracker) ConsecutiveFailures(key string) int {
+	h, ok := t.data[key]
+	if !ok {
+		return 0
+	}
+	return h.ConsecutiveFailures()
+}
+
+// Classify returns the classification of a currently failing test, based
+// on the history recorded for key so far.
+func (t *Tracker) Classify(key string) Classification {
+	h, ok := t.data[key]
+	if !ok || len(h.Outcomes) <= 1 {
+		return NewlyFailing
+	}
+	if h.ConsecutiveFailures() == len(h.Outcomes) {
+		return ConsistentlyFailing
+	}
+	if h.FailRatio() >= t.flakyThreshold {
+		t.logger.Info("test classified as flaky", logging.Fields{"test": key, "fail_ratio": h.FailRatio()})
+		return Flaky
+	}
+	return NewlyFailing
+}
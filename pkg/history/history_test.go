@@ -0,0 +1,67 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/istratem/gotestsum/pkg/logging"
+)
+
+func TestTracker_ClassifyNewlyFailing(t *testing.T) {
+	tr := NewTracker(nil, 20, 0.2, logging.NewNopLogger())
+	tr.data = map[string]*TestHistory{}
+	tr.Record("pkg/TestFoo", false, time.Time{})
+
+	if got := tr.Classify("pkg/TestFoo"); got != NewlyFailing {
+		t.Fatalf("Classify() = %v, want %v", got, NewlyFailing)
+	}
+}
+
+func TestTracker_ClassifyConsistentlyFailing(t *testing.T) {
+	tr := NewTracker(nil, 20, 0.2, logging.NewNopLogger())
+	tr.data = map[string]*TestHistory{}
+	for i := 0; i < 5; i++ {
+		tr.Record("pkg/TestFoo", false, time.Time{})
+	}
+
+	if got := tr.Classify("pkg/TestFoo"); got != ConsistentlyFailing {
+		t.Fatalf("Classify() = %v, want %v", got, ConsistentlyFailing)
+	}
+}
+
+func TestTracker_ClassifyFlaky(t *testing.T) {
+	tr := NewTracker(nil, 20, 0.2, logging.NewNopLogger())
+	tr.data = map[string]*TestHistory{}
+	outcomes := []bool{true, false, true, false, true, true, true, true}
+	for _, passed := range outcomes {
+		tr.Record("pkg/TestFoo", passed, time.Time{})
+	}
+
+	if got := tr.Classify("pkg/TestFoo"); got != Flaky {
+		t.Fatalf("Classify() = %v, want %v", got, Flaky)
+	}
+}
+
+func TestTracker_RecordTrimsToWindow(t *testing.T) {
+	tr := NewTracker(nil, 3, 0.2, logging.NewNopLogger())
+	tr.data = map[string]*TestHistory{}
+	for i := 0; i < 10; i++ {
+		tr.Record("pkg/TestFoo", false, time.Time{})
+	}
+
+	if got := len(tr.data["pkg/TestFoo"].Outcomes); got != 3 {
+		t.Fatalf("len(Outcomes) = %d, want 3", got)
+	}
+}
+
+func TestTracker_ConsecutiveFailures(t *testing.T) {
+	tr := NewTracker(nil, 20, 0.2, logging.NewNopLogger())
+	tr.data = map[string]*TestHistory{}
+	tr.Record("pkg/TestFoo", true, time.Time{})
+	tr.Record("pkg/TestFoo", false, time.Time{})
+	tr.Record("pkg/TestFoo", false, time.Time{})
+
+	if got := tr.ConsecutiveFailures("pkg/TestFoo"); got != 2 {
+		t.Fatalf("ConsecutiveFailures() = %d, want 2", got)
+	}
+}
@@ -0,0 +1,21 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WriteQuarantineFile writes the names of the flaky tests to quarantine
+// to path, one per line, after a leading "#" header line. The file is
+// meant to be turned into a `go test -skip` pattern (e.g.
+// `-skip "$(grep -v '^#' quarantine.txt | paste -sd '|' -)"`) by the
+// caller's CI script.
+func WriteQuarantineFile(path string, testNames []string) error {
+	var b strings.Builder
+	b.WriteString("# Auto-generated by gotestsum --quarantine-file. Do not edit by hand.\n")
+	for _, name := range testNames {
+		fmt.Fprintln(&b, name)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
@@ -0,0 +1,105 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrObjectNotFound is returned by an ObjectStoreClient when the
+// requested key has never been written, so RemoteStore.Load can tell
+// "no history yet" apart from a real read failure.
+var ErrObjectNotFound = errors.New("history: object not found")
+
+// ObjectStoreClient is the minimal surface RemoteStore needs from a
+// remote object store. GetObject must return ErrObjectNotFound (or an
+// error wrapping it) when key has never been written.
+type ObjectStoreClient interface {
+	GetObject(key string) ([]byte, error)
+	PutObject(key string, data []byte) error
+}
+
+// RemoteStore persists history as a single JSON object in a remote
+// object store, for sharing history across CI runners that don't share
+// a filesystem.
+type RemoteStore struct {
+	Client ObjectStoreClient
+	Key    string
+}
+
+func (s RemoteStore) Load() (map[string]*TestHistory, error) {
+	data, err := s.Client.GetObject(s.Key)
+	if errors.Is(err, ErrObjectNotFound) {
+		return map[string]*TestHistory{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading remote history object %s: %w", s.Key, err)
+	}
+
+	history := map[string]*TestHistory{}
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("parsing remote history object %s: %w", s.Key, err)
+	}
+	return history, nil
+}
+
+func (s RemoteStore) Save(history map[string]*TestHistory) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling history: %w", err)
+	}
+	if err := s.Client.PutObject(s.Key, data); err != nil {
+		return fmt.Errorf("writing remote history object %s: %w", s.Key, err)
+	}
+	return nil
+}
+
+// HTTPObjectStore is an ObjectStoreClient backed by plain HTTP GET/PUT
+// requests against baseURL+"/"+key, for remote stores that expose that
+// surface directly (e.g. a signed bucket URL, or a simple HTTP history
+// server).
+type HTTPObjectStore struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewHTTPObjectStore builds an HTTPObjectStore using http.DefaultClient.
+func NewHTTPObjectStore(baseURL string) HTTPObjectStore {
+	return HTTPObjectStore{BaseURL: baseURL, Client: http.DefaultClient}
+}
+
+func (s HTTPObjectStore) GetObject(key string) ([]byte, error) {
+	resp, err := s.Client.Get(s.BaseURL + "/" + key)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", key, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (s HTTPObjectStore) PutObject(key string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, s.BaseURL+"/"+key, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("PUT %s: unexpected status %s", key, resp.Status)
+	}
+	return nil
+}
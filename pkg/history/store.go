@@ -0,0 +1,40 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// JSONFileStore persists history as a single JSON file on the local
+// filesystem.
+type JSONFileStore struct {
+	Path string
+}
+
+func (s JSONFileStore) Load() (map[string]*TestHistory, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return map[string]*TestHistory{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading history file %s: %w", s.Path, err)
+	}
+
+	history := map[string]*TestHistory{}
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("parsing history file %s: %w", s.Path, err)
+	}
+	return history, nil
+}
+
+func (s JSONFileStore) Save(history map[string]*TestHistory) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling history: %w", err)
+	}
+	if err := os.WriteFile(s.Path, data, 0o644); err != nil {
+		return fmt.Errorf("writing history file %s: %w", s.Path, err)
+	}
+	return nil
+}
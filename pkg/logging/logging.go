@@ -0,0 +1,42 @@
+// Package logging defines the structured logger used across gotestsum,
+// so events like "issue posted" or "rate-limited by GitHub" carry typed
+// fields (repo, issue_number, test, duration_ms, ...) instead of being
+// interpolated into a free-form message string.
+package logging
+
+// Fields is a set of structured key/value pairs attached to a log event.
+type Fields map[string]interface{}
+
+// Logger is the leveled, structured logging interface every package in
+// gotestsum depends on. NewLogger's logrus, zap and slog adapters all
+// implement it.
+type Logger interface {
+	Debug(msg string, fields Fields)
+	Info(msg string, fields Fields)
+	Error(msg string, fields Fields)
+}
+
+// NewLogger builds a Logger backed by backend ("logrus", "zap", or
+// "slog"; "logrus" is the default for an unrecognized or empty backend),
+// formatting output as "text" or "json".
+func NewLogger(backend, format string) (Logger, error) {
+	switch backend {
+	case "zap":
+		return NewZapLogger(format)
+	case "slog":
+		return NewSlogLogger(format), nil
+	default:
+		return NewLogrusLogger(format), nil
+	}
+}
+
+// nopLogger discards every event. It backs NewNopLogger, used where a
+// Logger is required but nothing should be printed, e.g. package tests.
+type nopLogger struct{}
+
+// NewNopLogger returns a Logger that discards everything written to it.
+func NewNopLogger() Logger { return nopLogger{} }
+
+func (nopLogger) Debug(string, Fields) {}
+func (nopLogger) Info(string, Fields)  {}
+func (nopLogger) Error(string, Fields) {}
@@ -0,0 +1,32 @@
+package logging
+
+import "github.com/sirupsen/logrus"
+
+// logrusLogger is the default Logger backend, wrapping the
+// logrus.Logger main.go already configures via --debug.
+type logrusLogger struct {
+	log *logrus.Logger
+}
+
+// NewLogrusLogger builds a Logger backed by logrus.StandardLogger,
+// formatted as "json" when format == "json" and as logrus's default
+// text formatter otherwise.
+func NewLogrusLogger(format string) Logger {
+	l := logrus.StandardLogger()
+	if format == "json" {
+		l.SetFormatter(&logrus.JSONFormatter{})
+	}
+	return &logrusLogger{log: l}
+}
+
+func (l *logrusLogger) Debug(msg string, fields Fields) {
+	l.log.WithFields(logrus.Fields(fields)).Debug(msg)
+}
+
+func (l *logrusLogger) Info(msg string, fields Fields) {
+	l.log.WithFields(logrus.Fields(fields)).Info(msg)
+}
+
+func (l *logrusLogger) Error(msg string, fields Fields) {
+	l.log.WithFields(logrus.Fields(fields)).Error(msg)
+}
@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"log/slog"
+	"os"
+)
+
+// slogLogger is the Logger backend for the standard library's log/slog,
+// for users who'd rather not pull in an extra logging dependency.
+type slogLogger struct {
+	log *slog.Logger
+}
+
+// NewSlogLogger builds a Logger backed by log/slog, writing JSON lines
+// when format == "json" and slog's default text handler otherwise.
+func NewSlogLogger(format string) Logger {
+	var handler slog.Handler
+	if format == "json" {
+		handler = slog.NewJSONHandler(os.Stderr, nil)
+	} else {
+		handler = slog.NewTextHandler(os.Stderr, nil)
+	}
+	return &slogLogger{log: slog.New(handler)}
+}
+
+func (l *slogLogger) Debug(msg string, fields Fields) {
+	l.log.Debug(msg, toAttrs(fields)...)
+}
+
+func (l *slogLogger) Info(msg string, fields Fields) {
+	l.log.Info(msg, toAttrs(fields)...)
+}
+
+func (l *slogLogger) Error(msg string, fields Fields) {
+	l.log.Error(msg, toAttrs(fields)...)
+}
+
+// toAttrs flattens Fields into slog's alternating key/value argument list.
+func toAttrs(fields Fields) []interface{} {
+	args := make([]interface{}, 0, len(fields)*2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+	return args
+}
@@ -0,0 +1,45 @@
+package logging
+
+import "go.uber.org/zap"
+
+// zapLogger is the Logger backend for users who already standardized
+// their CI pipeline's logging on zap.
+type zapLogger struct {
+	log *zap.SugaredLogger
+}
+
+// NewZapLogger builds a Logger backed by zap, using its production JSON
+// config when format == "json" and its human-friendly development
+// config otherwise.
+func NewZapLogger(format string) (Logger, error) {
+	cfg := zap.NewDevelopmentConfig()
+	if format == "json" {
+		cfg = zap.NewProductionConfig()
+	}
+	log, err := cfg.Build()
+	if err != nil {
+		return nil, err
+	}
+	return &zapLogger{log: log.Sugar()}, nil
+}
+
+func (l *zapLogger) Debug(msg string, fields Fields) {
+	l.log.Debugw(msg, toArgs(fields)...)
+}
+
+func (l *zapLogger) Info(msg string, fields Fields) {
+	l.log.Infow(msg, toArgs(fields)...)
+}
+
+func (l *zapLogger) Error(msg string, fields Fields) {
+	l.log.Errorw(msg, toArgs(fields)...)
+}
+
+// toArgs flattens Fields into zap's alternating key/value argument list.
+func toArgs(fields Fields) []interface{} {
+	args := make([]interface{}, 0, len(fields)*2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+	return args
+}
@@ -0,0 +1,61 @@
+package operator
+
+import (
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/google/go-github/v45/github"
+	gitlabsdk "github.com/xanzy/go-gitlab"
+)
+
+// TestBranchLabelRoundTrip checks that the "branch:<name>" label CIContext.Labels
+// attaches to a newly filed issue (see FailedTest.WithCI) is the same one each
+// backend's GetTestIssues extracts back out, in whatever shape that backend's
+// SDK represents labels as. A mismatch here is exactly the bug class that made
+// every still-failing test look simultaneously solved and new.
+func TestBranchLabelRoundTrip(t *testing.T) {
+	const branch = "feature/widgets"
+	labels := CIContext{Branch: branch}.Labels()
+
+	t.Run("github", func(t *testing.T) {
+		var ghLabels []*github.Label
+		for _, l := range labels {
+			name := l
+			ghLabels = append(ghLabels, &github.Label{Name: &name})
+		}
+		if got := branchFromLabels(ghLabels); got != branch {
+			t.Fatalf("branchFromLabels() = %q, want %q", got, branch)
+		}
+	})
+
+	t.Run("gitlab", func(t *testing.T) {
+		var glLabels gitlabsdk.Labels
+		glLabels = append(glLabels, labels...)
+		if got := branchFromLabelList(glLabels); got != branch {
+			t.Fatalf("branchFromLabelList() = %q, want %q", got, branch)
+		}
+	})
+
+	t.Run("gitea", func(t *testing.T) {
+		var giteaLabels []*gitea.Label
+		for _, l := range labels {
+			giteaLabels = append(giteaLabels, &gitea.Label{Name: l})
+		}
+		if got := branchFromGiteaLabels(giteaLabels); got != branch {
+			t.Fatalf("branchFromGiteaLabels() = %q, want %q", got, branch)
+		}
+	})
+
+	t.Run("jira", func(t *testing.T) {
+		if got := branchFromJiraLabels(labels); got != branch {
+			t.Fatalf("branchFromJiraLabels() = %q, want %q", got, branch)
+		}
+	})
+}
+
+func TestBranchLabelRoundTrip_NoBranch(t *testing.T) {
+	labels := CIContext{}.Labels()
+	if got := branchFromJiraLabels(labels); got != "" {
+		t.Fatalf("branchFromJiraLabels() = %q, want empty", got)
+	}
+}
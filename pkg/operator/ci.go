@@ -0,0 +1,185 @@
+package operator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/istratem/gotestsum/pkg/options"
+)
+
+// CIContext carries the VCS and CI metadata that gets attached to every
+// newly filed issue, so a reader can jump straight from the issue to the
+// build and commit that produced it.
+type CIContext struct {
+	Name          string // github-actions, gitlab-ci, circleci, buildkite, jenkins
+	Branch        string
+	SHA           string
+	CommitMessage string
+	PR            string
+	BuildNumber   string
+	BuildURL      string
+	ChangedFiles  []string
+}
+
+// DetectCI auto-detects the CI provider from well-known environment
+// variables, then applies any --branch/--sha/--pr/--build-url/--ci-name
+// overrides from opts, and finally fills CommitMessage from the local
+// git repository when possible.
+func DetectCI(opts *options.Options) CIContext {
+	var ctx CIContext
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") != "":
+		ctx = CIContext{
+			Name:         "github-actions",
+			Branch:       os.Getenv("GITHUB_REF_NAME"),
+			SHA:          os.Getenv("GITHUB_SHA"),
+			PR:           prFromGitHubRef(os.Getenv("GITHUB_REF")),
+			BuildNumber:  os.Getenv("GITHUB_RUN_NUMBER"),
+			BuildURL:     fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")),
+			ChangedFiles: changedFiles(os.Getenv("GITHUB_BASE_REF")),
+		}
+	case os.Getenv("GITLAB_CI") != "":
+		ctx = CIContext{
+			Name:         "gitlab-ci",
+			Branch:       os.Getenv("CI_COMMIT_REF_NAME"),
+			SHA:          os.Getenv("CI_COMMIT_SHA"),
+			PR:           os.Getenv("CI_MERGE_REQUEST_IID"),
+			BuildNumber:  os.Getenv("CI_JOB_ID"),
+			BuildURL:     os.Getenv("CI_JOB_URL"),
+			ChangedFiles: changedFiles(os.Getenv("CI_MERGE_REQUEST_DIFF_BASE_SHA")),
+		}
+	case os.Getenv("CIRCLECI") != "":
+		ctx = CIContext{
+			Name:         "circleci",
+			Branch:       os.Getenv("CIRCLE_BRANCH"),
+			SHA:          os.Getenv("CIRCLE_SHA1"),
+			PR:           os.Getenv("CIRCLE_PR_NUMBER"),
+			BuildNumber:  os.Getenv("CIRCLE_BUILD_NUM"),
+			BuildURL:     os.Getenv("CIRCLE_BUILD_URL"),
+			ChangedFiles: changedFiles(""),
+		}
+	case os.Getenv("BUILDKITE") != "":
+		ctx = CIContext{
+			Name:         "buildkite",
+			Branch:       os.Getenv("BUILDKITE_BRANCH"),
+			SHA:          os.Getenv("BUILDKITE_COMMIT"),
+			PR:           os.Getenv("BUILDKITE_PULL_REQUEST"),
+			BuildNumber:  os.Getenv("BUILDKITE_BUILD_NUMBER"),
+			BuildURL:     os.Getenv("BUILDKITE_BUILD_URL"),
+			ChangedFiles: changedFiles(""),
+		}
+	case os.Getenv("JENKINS_URL") != "":
+		ctx = CIContext{
+			Name:         "jenkins",
+			Branch:       os.Getenv("GIT_BRANCH"),
+			SHA:          os.Getenv("GIT_COMMIT"),
+			PR:           os.Getenv("CHANGE_ID"),
+			BuildNumber:  os.Getenv("BUILD_NUMBER"),
+			BuildURL:     os.Getenv("BUILD_URL"),
+			ChangedFiles: changedFiles(""),
+		}
+	}
+
+	if opts.CIName != "" {
+		ctx.Name = opts.CIName
+	}
+	if opts.Branch != "" {
+		ctx.Branch = opts.Branch
+	}
+	if opts.SHA != "" {
+		ctx.SHA = opts.SHA
+	}
+	if opts.PR != "" {
+		ctx.PR = opts.PR
+	}
+	if opts.BuildURL != "" {
+		ctx.BuildURL = opts.BuildURL
+	}
+
+	ctx.CommitMessage = commitMessage(ctx.SHA)
+	return ctx
+}
+
+// prFromGitHubRef extracts the PR number out of a "refs/pull/123/merge"
+// style GITHUB_REF, returning "" when the ref isn't a pull request.
+func prFromGitHubRef(ref string) string {
+	parts := strings.Split(ref, "/")
+	if len(parts) >= 3 && parts[1] == "pull" {
+		return parts[2]
+	}
+	return ""
+}
+
+// changedFiles shells out to git for the files touched since base,
+// falling back to comparing against HEAD~1 when no PR base ref/sha is
+// known for the current CI provider. Any git failure (shallow clone
+// missing the base ref, no git repository at all, ...) yields an empty
+// list rather than an error, since this is best-effort context for
+// policy rules, not something run should fail over.
+func changedFiles(base string) []string {
+	if base == "" {
+		base = "HEAD~1"
+	}
+	out, err := exec.Command("git", "diff", "--name-only", base, "HEAD").Output()
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files
+}
+
+// commitMessage shells out to git for the short commit message of sha,
+// falling back to HEAD when sha is empty. CI providers rarely expose the
+// commit message via an environment variable.
+func commitMessage(sha string) string {
+	if sha == "" {
+		sha = "HEAD"
+	}
+	out, err := exec.Command("git", "log", "-1", "--pretty=%s", sha).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// EnvironmentSection renders the CI context as the "Environment" section
+// appended to the body of every newly filed issue.
+func (c CIContext) EnvironmentSection() string {
+	var b strings.Builder
+	b.WriteString("\n\n## Environment\n")
+	writeField(&b, "Branch", c.Branch)
+	writeField(&b, "Commit", c.SHA)
+	writeField(&b, "Commit message", c.CommitMessage)
+	writeField(&b, "Pull request", c.PR)
+	writeField(&b, "CI", c.Name)
+	writeField(&b, "Build number", c.BuildNumber)
+	writeField(&b, "Build URL", c.BuildURL)
+	return b.String()
+}
+
+// Labels returns the labels used to scope duplicate detection and to
+// surface the CI context in the tracker's UI.
+func (c CIContext) Labels() []string {
+	var labels []string
+	if c.Branch != "" {
+		labels = append(labels, "branch:"+c.Branch)
+	}
+	if c.Name != "" {
+		labels = append(labels, "ci:"+c.Name)
+	}
+	return labels
+}
+
+func writeField(b *strings.Builder, name, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(b, "- **%s**: %s\n", name, value)
+}
@@ -0,0 +1,23 @@
+package operator
+
+import "testing"
+
+func TestPrFromGitHubRef(t *testing.T) {
+	cases := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{"pull request ref", "refs/pull/123/merge", "123"},
+		{"branch ref", "refs/heads/main", ""},
+		{"tag ref", "refs/tags/v1.0.0", ""},
+		{"empty ref", "", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := prFromGitHubRef(tc.ref); got != tc.want {
+				t.Fatalf("prFromGitHubRef(%q) = %q, want %q", tc.ref, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package operator
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CredentialStore resolves the auth token for a tracker from one of a few
+// well-known sources, so a token never has to be passed on the command
+// line in plain text.
+type CredentialStore interface {
+	// Token returns the credential for the given environment variable
+	// name (e.g. "GOTESTSUM_GITLAB_TOKEN").
+	Token(envVar string) (string, error)
+}
+
+// EnvCredentialStore reads the token straight out of the process
+// environment. It is the default store.
+type EnvCredentialStore struct{}
+
+func (EnvCredentialStore) Token(envVar string) (string, error) {
+	if v := os.Getenv(envVar); v != "" {
+		return v, nil
+	}
+	return "", fmt.Errorf("environment variable %s is not set", envVar)
+}
+
+// FileCredentialStore reads the token from the first line of a file,
+// e.g. a token mounted as a CI secret file.
+type FileCredentialStore struct {
+	Path string
+}
+
+func (f FileCredentialStore) Token(envVar string) (string, error) {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return "", fmt.Errorf("reading credential file %s: %w", f.Path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if scanner.Scan() {
+		return strings.TrimSpace(scanner.Text()), nil
+	}
+	return "", fmt.Errorf("credential file %s is empty", f.Path)
+}
+
+// GitCredentialStore delegates to `git credential fill`, so tokens
+// already stored by a user's git credential helper (keychain, manager,
+// netrc, ...) can be reused.
+type GitCredentialStore struct {
+	URL string
+}
+
+func (g GitCredentialStore) Token(envVar string) (string, error) {
+	u, err := url.Parse(g.URL)
+	if err != nil {
+		return "", fmt.Errorf("parsing tracker url %s: %w", g.URL, err)
+	}
+
+	input := fmt.Sprintf("protocol=%s\nhost=%s\n\n", u.Scheme, u.Host)
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(input)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git credential fill: %w", err)
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "password=") {
+			return strings.TrimPrefix(line, "password="), nil
+		}
+	}
+	return "", fmt.Errorf("git credential fill returned no password")
+}
@@ -0,0 +1,74 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/istratem/gotestsum/pkg/logging"
+	"github.com/istratem/gotestsum/pkg/options"
+)
+
+// tokenEnvVar returns the environment variable a tracker's token is read
+// from when --token is not set on the command line.
+func tokenEnvVar(tracker string) string {
+	switch tracker {
+	case "gitlab":
+		return "GOTESTSUM_GITLAB_TOKEN"
+	case "gitea":
+		return "GOTESTSUM_GITEA_TOKEN"
+	case "jira":
+		return "GOTESTSUM_JIRA_TOKEN"
+	default:
+		return "GOTESTSUM_GITHUB_TOKEN"
+	}
+}
+
+// resolveToken returns opts.Token if set. Otherwise it returns an empty
+// token when opts.GitUnAuth is set, and falls back to store when not.
+func resolveToken(opts *options.Options, store CredentialStore) (string, error) {
+	if opts.Token != "" {
+		return opts.Token, nil
+	}
+	if opts.GitUnAuth {
+		return "", nil
+	}
+	return store.Token(tokenEnvVar(opts.Tracker))
+}
+
+// NewTracker builds and authenticates the IssueTracker selected by
+// opts.Tracker ("github" by default). The token is read from --token,
+// falling back to the tracker's credential store (env, file, or git
+// credential, depending on opts.CredentialSource).
+func NewTracker(opts *options.Options, ctx context.Context, logger logging.Logger) (IssueTracker, error) {
+	var store CredentialStore = EnvCredentialStore{}
+	switch opts.CredentialSource {
+	case "file":
+		store = FileCredentialStore{Path: opts.CredentialFile}
+	case "git-credential":
+		store = GitCredentialStore{URL: opts.TrackerURL}
+	}
+
+	token, err := resolveToken(opts, store)
+	if err != nil && !opts.GitUnAuth {
+		return nil, fmt.Errorf("resolving %s credentials: %w", opts.Tracker, err)
+	}
+
+	var tracker IssueTracker
+	switch opts.Tracker {
+	case "gitlab":
+		tracker = NewGitLabTracker(opts.Owner+"/"+opts.Repo, token, opts.TrackerURL, logger)
+	case "gitea":
+		tracker = NewGiteaTracker(opts.Owner, opts.Repo, token, opts.TrackerURL, logger)
+	case "jira":
+		tracker = NewJiraTracker(opts.Repo, "", opts.TrackerURL, opts.Owner, token, logger)
+	case "", "github":
+		tracker = NewGitHubTracker(opts.Owner, opts.Repo, token, opts.TrackerURL, ctx, logger)
+	default:
+		return nil, fmt.Errorf("unknown tracker %q", opts.Tracker)
+	}
+
+	if err := tracker.Authenticate(); err != nil {
+		return nil, err
+	}
+	return tracker, nil
+}
@@ -0,0 +1,69 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/istratem/gotestsum/pkg/options"
+)
+
+type fakeCredentialStore struct {
+	token string
+	err   error
+}
+
+func (f fakeCredentialStore) Token(string) (string, error) {
+	return f.token, f.err
+}
+
+func TestResolveToken_PrefersExplicitToken(t *testing.T) {
+	opts := &options.Options{Token: "explicit-token"}
+	got, err := resolveToken(opts, fakeCredentialStore{token: "store-token"})
+	if err != nil {
+		t.Fatalf("resolveToken: %v", err)
+	}
+	if got != "explicit-token" {
+		t.Fatalf("resolveToken() = %q, want %q", got, "explicit-token")
+	}
+}
+
+func TestResolveToken_FallsBackToStore(t *testing.T) {
+	opts := &options.Options{}
+	got, err := resolveToken(opts, fakeCredentialStore{token: "store-token"})
+	if err != nil {
+		t.Fatalf("resolveToken: %v", err)
+	}
+	if got != "store-token" {
+		t.Fatalf("resolveToken() = %q, want %q", got, "store-token")
+	}
+}
+
+func TestResolveToken_UnauthSkipsStore(t *testing.T) {
+	opts := &options.Options{GitUnAuth: true}
+	got, err := resolveToken(opts, fakeCredentialStore{err: fakeErr{}})
+	if err != nil {
+		t.Fatalf("resolveToken: %v", err)
+	}
+	if got != "" {
+		t.Fatalf("resolveToken() = %q, want empty", got)
+	}
+}
+
+type fakeErr struct{}
+
+func (fakeErr) Error() string { return "credential store unavailable" }
+
+func TestTokenEnvVar(t *testing.T) {
+	cases := map[string]string{
+		"gitlab":  "GOTESTSUM_GITLAB_TOKEN",
+		"gitea":   "GOTESTSUM_GITEA_TOKEN",
+		"jira":    "GOTESTSUM_JIRA_TOKEN",
+		"github":  "GOTESTSUM_GITHUB_TOKEN",
+		"":        "GOTESTSUM_GITHUB_TOKEN",
+		"unknown": "GOTESTSUM_GITHUB_TOKEN",
+	}
+	for tracker, want := range cases {
+		if got := tokenEnvVar(tracker); got != want {
+			t.Errorf("tokenEnvVar(%q) = %q, want %q", tracker, got, want)
+		}
+	}
+}
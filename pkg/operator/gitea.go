@@ -0,0 +1,150 @@
+package operator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/istratem/gotestsum/pkg/logging"
+)
+
+const giteaIssueLabel = "gotestsum"
+
+// GiteaTracker is the IssueTracker implementation for Gitea instances.
+// Gitea has no hosted SaaS equivalent, so URL is always required.
+type GiteaTracker struct {
+	Owner string
+	Repo  string
+	Token string
+	URL   string
+
+	client *gitea.Client
+	logger logging.Logger
+}
+
+// NewGiteaTracker builds a tracker for the given owner/repo on the Gitea
+// instance at url.
+func NewGiteaTracker(owner, repo, token, url string, logger logging.Logger) *GiteaTracker {
+	return &GiteaTracker{Owner: owner, Repo: repo, Token: token, URL: url, logger: logger}
+}
+
+func (g *GiteaTracker) Authenticate() error {
+	client, err := gitea.NewClient(g.URL, gitea.SetToken(g.Token))
+	if err != nil {
+		return fmt.Errorf("authenticating with gitea: %w", err)
+	}
+	g.client = client
+	return nil
+}
+
+func (g *GiteaTracker) GetTestIssues() ([]*FailedTest, error) {
+	issues, _, err := g.client.ListRepoIssues(g.Owner, g.Repo, gitea.ListIssueOption{
+		State:  gitea.StateOpen,
+		Labels: []string{giteaIssueLabel},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing gitea issues: %w", err)
+	}
+
+	var tests []*FailedTest
+	for _, issue := range issues {
+		tests = append(tests, &FailedTest{
+			Title:     issue.Title,
+			TrackerID: strconv.FormatInt(issue.Index, 10),
+			CI:        CIContext{Branch: branchFromGiteaLabels(issue.Labels)},
+			IssueURL:  issue.HTMLURL,
+		})
+	}
+	return tests, nil
+}
+
+// branchFromGiteaLabels extracts the "branch:<name>" label set by
+// CIContext.Labels, so duplicate detection can be scoped per-branch.
+func branchFromGiteaLabels(labels []*gitea.Label) string {
+	for _, l := range labels {
+		if strings.HasPrefix(l.Name, "branch:") {
+			return strings.TrimPrefix(l.Name, "branch:")
+		}
+	}
+	return ""
+}
+
+func (g *GiteaTracker) PostNewIssue(t *FailedTest) error {
+	body := t.Output + t.CI.EnvironmentSection()
+	labelIDs, err := g.labelIDs(append([]string{giteaIssueLabel}, t.Labels...))
+	if err != nil {
+		return err
+	}
+	created, _, err := g.client.CreateIssue(g.Owner, g.Repo, gitea.CreateIssueOption{
+		Title:  t.Title,
+		Body:   body,
+		Labels: labelIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("creating gitea issue: %w", err)
+	}
+	t.IssueURL = created.HTMLURL
+	g.logger.Info("issue posted", logging.Fields{
+		"repo":         g.Owner + "/" + g.Repo,
+		"issue_number": created.Index,
+		"test":         t.Title,
+	})
+	return nil
+}
+
+// labelIDs resolves names to the repo's label IDs, creating any label
+// that doesn't exist yet, since Gitea's issue API takes label IDs rather
+// than names.
+func (g *GiteaTracker) labelIDs(names []string) ([]int64, error) {
+	existing, _, err := g.client.ListRepoLabels(g.Owner, g.Repo, gitea.ListLabelsOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing gitea labels: %w", err)
+	}
+	byName := make(map[string]int64, len(existing))
+	for _, l := range existing {
+		byName[l.Name] = l.ID
+	}
+
+	var ids []int64
+	for _, name := range names {
+		if id, ok := byName[name]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		created, _, err := g.client.CreateLabel(g.Owner, g.Repo, gitea.CreateLabelOption{
+			Name:  name,
+			Color: "#ededed",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating gitea label %q: %w", name, err)
+		}
+		byName[name] = created.ID
+		ids = append(ids, created.ID)
+	}
+	return ids, nil
+}
+
+func (g *GiteaTracker) CloseSolvedIssue(t *FailedTest) error {
+	if t.TrackerID == "" {
+		return fmt.Errorf("no gitea issue index recorded for test %q", t.Title)
+	}
+	index, err := strconv.ParseInt(t.TrackerID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid gitea issue index %q: %w", t.TrackerID, err)
+	}
+
+	closed := gitea.StateClosed
+	_, _, err = g.client.EditIssue(g.Owner, g.Repo, index, gitea.EditIssueOption{
+		State: &closed,
+	})
+	if err != nil {
+		return fmt.Errorf("closing gitea issue: %w", err)
+	}
+	g.logger.Info("issue closed", logging.Fields{
+		"repo":         g.Owner + "/" + g.Repo,
+		"issue_number": index,
+		"test":         t.Title,
+	})
+	return nil
+}
@@ -0,0 +1,147 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/istratem/gotestsum/pkg/logging"
+	"golang.org/x/oauth2"
+)
+
+const githubIssueLabel = "gotestsum"
+
+// GitHubTracker is the IssueTracker implementation for GitHub and GitHub
+// Enterprise.
+type GitHubTracker struct {
+	Owner string
+	Repo  string
+	Token string
+	URL   string
+
+	ctx    context.Context
+	client *github.Client
+	logger logging.Logger
+}
+
+// NewGitHubTracker builds a tracker for the given owner/repo. If url is
+// non-empty it is treated as the base URL of a GitHub Enterprise
+// instance, otherwise github.com is used.
+func NewGitHubTracker(owner, repo, token, url string, ctx context.Context, logger logging.Logger) *GitHubTracker {
+	return &GitHubTracker{Owner: owner, Repo: repo, Token: token, URL: url, ctx: ctx, logger: logger}
+}
+
+func (g *GitHubTracker) Authenticate() error {
+	var httpClient *http.Client
+	if g.Token != "" {
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: g.Token})
+		httpClient = oauth2.NewClient(g.ctx, ts)
+	}
+
+	if g.URL != "" {
+		client, err := github.NewEnterpriseClient(g.URL, g.URL, httpClient)
+		if err != nil {
+			return fmt.Errorf("configuring github enterprise url: %w", err)
+		}
+		g.client = client
+		return nil
+	}
+
+	g.client = github.NewClient(httpClient)
+	return nil
+}
+
+func (g *GitHubTracker) GetTestIssues() ([]*FailedTest, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:  "open",
+		Labels: []string{githubIssueLabel},
+	}
+	issues, resp, err := g.client.Issues.ListByRepo(g.ctx, g.Owner, g.Repo, opts)
+	if err != nil {
+		g.logRateLimit(resp)
+		return nil, fmt.Errorf("listing github issues: %w", err)
+	}
+
+	var tests []*FailedTest
+	for _, issue := range issues {
+		tests = append(tests, &FailedTest{
+			Title:     issue.GetTitle(),
+			TrackerID: strconv.Itoa(issue.GetNumber()),
+			CI:        CIContext{Branch: branchFromLabels(issue.Labels)},
+			IssueURL:  issue.GetHTMLURL(),
+		})
+	}
+	return tests, nil
+}
+
+func (g *GitHubTracker) PostNewIssue(t *FailedTest) error {
+	body := t.Output + t.CI.EnvironmentSection()
+	labels := append([]string{githubIssueLabel}, t.Labels...)
+	req := &github.IssueRequest{
+		Title:  &t.Title,
+		Body:   &body,
+		Labels: &labels,
+	}
+	created, resp, err := g.client.Issues.Create(g.ctx, g.Owner, g.Repo, req)
+	if err != nil {
+		g.logRateLimit(resp)
+		return fmt.Errorf("creating github issue: %w", err)
+	}
+	t.IssueURL = created.GetHTMLURL()
+	g.logger.Info("issue posted", logging.Fields{
+		"repo":         g.Owner + "/" + g.Repo,
+		"issue_number": created.GetNumber(),
+		"test":         t.Title,
+	})
+	return nil
+}
+
+// logRateLimit logs a "rate-limited by github" event when resp shows the
+// request was throttled.
+func (g *GitHubTracker) logRateLimit(resp *github.Response) {
+	if resp == nil || resp.Rate.Remaining > 0 {
+		return
+	}
+	g.logger.Error("rate-limited by github", logging.Fields{
+		"repo":       g.Owner + "/" + g.Repo,
+		"reset_time": resp.Rate.Reset.String(),
+	})
+}
+
+// branchFromLabels extracts the "branch:<name>" label set by
+// CIContext.Labels, so duplicate detection can be scoped per-branch.
+func branchFromLabels(labels []*github.Label) string {
+	for _, l := range labels {
+		if name := l.GetName(); strings.HasPrefix(name, "branch:") {
+			return strings.TrimPrefix(name, "branch:")
+		}
+	}
+	return ""
+}
+
+func (g *GitHubTracker) CloseSolvedIssue(t *FailedTest) error {
+	if t.TrackerID == "" {
+		return fmt.Errorf("no github issue number recorded for test %q", t.Title)
+	}
+	number, err := strconv.Atoi(t.TrackerID)
+	if err != nil {
+		return fmt.Errorf("invalid github issue number %q: %w", t.TrackerID, err)
+	}
+
+	state := "closed"
+	req := &github.IssueRequest{State: &state}
+	_, resp, err := g.client.Issues.Edit(g.ctx, g.Owner, g.Repo, number, req)
+	if err != nil {
+		g.logRateLimit(resp)
+		return fmt.Errorf("closing github issue: %w", err)
+	}
+	g.logger.Info("issue closed", logging.Fields{
+		"repo":         g.Owner + "/" + g.Repo,
+		"issue_number": number,
+		"test":         t.Title,
+	})
+	return nil
+}
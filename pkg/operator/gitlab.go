@@ -0,0 +1,121 @@
+package operator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/istratem/gotestsum/pkg/logging"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+const gitlabIssueLabel = "gotestsum"
+
+// GitLabTracker is the IssueTracker implementation for GitLab.com and
+// self-hosted GitLab instances.
+type GitLabTracker struct {
+	ProjectPath string // "owner/repo"
+	Token       string
+	URL         string // self-hosted base URL, empty for gitlab.com
+
+	client *gitlab.Client
+	logger logging.Logger
+}
+
+// NewGitLabTracker builds a tracker for the given "owner/repo" project
+// path. If url is non-empty it is used as the self-hosted instance base
+// URL, otherwise gitlab.com is used.
+func NewGitLabTracker(projectPath, token, url string, logger logging.Logger) *GitLabTracker {
+	return &GitLabTracker{ProjectPath: projectPath, Token: token, URL: url, logger: logger}
+}
+
+func (g *GitLabTracker) Authenticate() error {
+	var opts []gitlab.ClientOptionFunc
+	if g.URL != "" {
+		opts = append(opts, gitlab.WithBaseURL(g.URL))
+	}
+	client, err := gitlab.NewClient(g.Token, opts...)
+	if err != nil {
+		return fmt.Errorf("authenticating with gitlab: %w", err)
+	}
+	g.client = client
+	return nil
+}
+
+func (g *GitLabTracker) GetTestIssues() ([]*FailedTest, error) {
+	opened := "opened"
+	labels := gitlab.LabelOptions{gitlabIssueLabel}
+	issues, _, err := g.client.Issues.ListProjectIssues(g.ProjectPath, &gitlab.ListProjectIssuesOptions{
+		State:  &opened,
+		Labels: &labels,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing gitlab issues: %w", err)
+	}
+
+	var tests []*FailedTest
+	for _, issue := range issues {
+		tests = append(tests, &FailedTest{
+			Title:     issue.Title,
+			TrackerID: strconv.Itoa(issue.IID),
+			CI:        CIContext{Branch: branchFromLabelList(issue.Labels)},
+			IssueURL:  issue.WebURL,
+		})
+	}
+	return tests, nil
+}
+
+func (g *GitLabTracker) PostNewIssue(t *FailedTest) error {
+	description := t.Output + t.CI.EnvironmentSection()
+	labels := gitlab.LabelOptions(append([]string{gitlabIssueLabel}, t.Labels...))
+	created, _, err := g.client.Issues.CreateIssue(g.ProjectPath, &gitlab.CreateIssueOptions{
+		Title:       &t.Title,
+		Description: &description,
+		Labels:      &labels,
+	})
+	if err != nil {
+		return fmt.Errorf("creating gitlab issue: %w", err)
+	}
+	t.IssueURL = created.WebURL
+	g.logger.Info("issue posted", logging.Fields{
+		"repo":         g.ProjectPath,
+		"issue_number": created.IID,
+		"test":         t.Title,
+	})
+	return nil
+}
+
+// branchFromLabelList extracts the "branch:<name>" label set by
+// CIContext.Labels, so duplicate detection can be scoped per-branch.
+func branchFromLabelList(labels gitlab.Labels) string {
+	for _, l := range labels {
+		if strings.HasPrefix(l, "branch:") {
+			return strings.TrimPrefix(l, "branch:")
+		}
+	}
+	return ""
+}
+
+func (g *GitLabTracker) CloseSolvedIssue(t *FailedTest) error {
+	if t.TrackerID == "" {
+		return fmt.Errorf("no gitlab issue iid recorded for test %q", t.Title)
+	}
+	iid, err := strconv.Atoi(t.TrackerID)
+	if err != nil {
+		return fmt.Errorf("invalid gitlab issue iid %q: %w", t.TrackerID, err)
+	}
+
+	closed := "close"
+	_, _, err = g.client.Issues.UpdateIssue(g.ProjectPath, iid, &gitlab.UpdateIssueOptions{
+		StateEvent: &closed,
+	})
+	if err != nil {
+		return fmt.Errorf("closing gitlab issue: %w", err)
+	}
+	g.logger.Info("issue closed", logging.Fields{
+		"repo":         g.ProjectPath,
+		"issue_number": iid,
+		"test":         t.Title,
+	})
+	return nil
+}
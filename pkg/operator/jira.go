@@ -0,0 +1,124 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+
+	jira "github.com/andygrunwald/go-jira"
+	"github.com/istratem/gotestsum/pkg/logging"
+)
+
+const jiraIssueLabel = "gotestsum"
+
+// JiraTracker is the IssueTracker implementation for Jira Cloud and
+// Jira Server. Issues are filed in the configured Project using the
+// configured IssueType (defaults to "Bug").
+type JiraTracker struct {
+	Project   string
+	IssueType string
+	URL       string
+	User      string
+	Token     string
+
+	client *jira.Client
+	logger logging.Logger
+}
+
+// NewJiraTracker builds a tracker that files issues in project on the
+// Jira instance at url.
+func NewJiraTracker(project, issueType, url, user, token string, logger logging.Logger) *JiraTracker {
+	if issueType == "" {
+		issueType = "Bug"
+	}
+	return &JiraTracker{Project: project, IssueType: issueType, URL: url, User: user, Token: token, logger: logger}
+}
+
+func (j *JiraTracker) Authenticate() error {
+	tp := jira.BasicAuthTransport{Username: j.User, Password: j.Token}
+	client, err := jira.NewClient(tp.Client(), j.URL)
+	if err != nil {
+		return fmt.Errorf("authenticating with jira: %w", err)
+	}
+	j.client = client
+	return nil
+}
+
+func (j *JiraTracker) GetTestIssues() ([]*FailedTest, error) {
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s" AND status != Done`, j.Project, jiraIssueLabel)
+	issues, _, err := j.client.Issue.Search(jql, nil)
+	if err != nil {
+		return nil, fmt.Errorf("searching jira issues: %w", err)
+	}
+
+	var tests []*FailedTest
+	for _, issue := range issues {
+		tests = append(tests, &FailedTest{
+			Title:     issue.Fields.Summary,
+			TrackerID: issue.Key,
+			CI:        CIContext{Branch: branchFromJiraLabels(issue.Fields.Labels)},
+			IssueURL:  j.URL + "/browse/" + issue.Key,
+		})
+	}
+	return tests, nil
+}
+
+// branchFromJiraLabels extracts the "branch:<name>" label set by
+// CIContext.Labels, so duplicate detection can be scoped per-branch.
+func branchFromJiraLabels(labels []string) string {
+	for _, l := range labels {
+		if strings.HasPrefix(l, "branch:") {
+			return strings.TrimPrefix(l, "branch:")
+		}
+	}
+	return ""
+}
+
+func (j *JiraTracker) PostNewIssue(t *FailedTest) error {
+	issue := &jira.Issue{
+		Fields: &jira.IssueFields{
+			Project:     jira.Project{Key: j.Project},
+			Type:        jira.IssueType{Name: j.IssueType},
+			Summary:     t.Title,
+			Description: t.Output + t.CI.EnvironmentSection(),
+			Labels:      append([]string{jiraIssueLabel}, t.Labels...),
+		},
+	}
+	created, _, err := j.client.Issue.Create(issue)
+	if err != nil {
+		return fmt.Errorf("creating jira issue: %w", err)
+	}
+	t.IssueURL = j.URL + "/browse/" + created.Key
+	j.logger.Info("issue posted", logging.Fields{
+		"repo":      j.Project,
+		"issue_key": created.Key,
+		"test":      t.Title,
+	})
+	return nil
+}
+
+func (j *JiraTracker) CloseSolvedIssue(t *FailedTest) error {
+	if t.TrackerID == "" {
+		return fmt.Errorf("no jira issue key recorded for test %q", t.Title)
+	}
+
+	transitions, _, err := j.client.Issue.GetTransitions(t.TrackerID)
+	if err != nil {
+		return fmt.Errorf("listing jira transitions: %w", err)
+	}
+
+	for _, tr := range transitions {
+		if tr.Name == "Done" || tr.To.Name == "Done" {
+			_, err := j.client.Issue.DoTransition(t.TrackerID, tr.ID)
+			if err != nil {
+				return fmt.Errorf("closing jira issue: %w", err)
+			}
+			j.logger.Info("issue closed", logging.Fields{
+				"repo":      j.Project,
+				"issue_key": t.TrackerID,
+				"test":      t.Title,
+			})
+			return nil
+		}
+	}
+	return fmt.Errorf("no Done transition available for jira issue %s", t.TrackerID)
+}
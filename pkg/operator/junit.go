@@ -0,0 +1,159 @@
+package operator
+
+import (
+	"encoding/xml"
+	"os"
+	"time"
+
+	"github.com/istratem/gotestsum/pkg/logging"
+	"github.com/istratem/gotestsum/pkg/options"
+)
+
+// FailedTest describes a single failing test case, as extracted from the
+// JUnit report, that a tracker may file or close an issue for.
+type FailedTest struct {
+	Title    string
+	Package  string
+	Output   string
+	Duration time.Duration
+
+	// TrackerID is the backend-specific identifier (issue number, IID,
+	// issue key, ...) of the issue filed for this test, populated by
+	// IssueTracker.GetTestIssues so CloseSolvedIssue can address it
+	// directly.
+	TrackerID string
+
+	// CI and Labels carry the VCS/CI metadata attached to newly filed
+	// issues, see CIContext. Labels is populated from CIContext.Labels
+	// (and extended by the history and policy steps in run()); each
+	// tracker adds its own bookkeeping label only to the labels it
+	// posts, not to this field. The "branch:" label it carries is what
+	// GetTestIssues reads back to scope duplicate detection per-branch.
+	CI       CIContext
+	Labels   []string
+	Severity string
+
+	// IssueURL, FirstSeen and LastSeen are filled in by the tracker (for
+	// IssueURL) and by pkg/history (for FirstSeen/LastSeen) when that
+	// information is available; they are the zero value otherwise.
+	IssueURL  string
+	FirstSeen time.Time
+	LastSeen  time.Time
+
+	// Classification is the pkg/history verdict for this test (flaky,
+	// newly_failing, consistently_failing), empty when history tracking
+	// is disabled.
+	Classification string
+}
+
+// Key identifies a test across runs, for use with pkg/history.
+func (t FailedTest) Key() string {
+	return t.Package + "/" + t.Title
+}
+
+// WithCI returns a copy of t with its CI context and labels set from ci,
+// so duplicate detection and the posted issue body can be scoped to the
+// branch/build the test failed on.
+func (t FailedTest) WithCI(ci CIContext) *FailedTest {
+	t.CI = ci
+	t.Labels = append(append([]string{}, t.Labels...), ci.Labels()...)
+	return &t
+}
+
+type junitTestSuites struct {
+	Suites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Package string          `xml:"package,attr"`
+	Cases   []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitOperator reads the JUnit XML report found at opts.JunitFile and
+// extracts the tests that failed. gotestsum.GoTestSum does not write that
+// file.
+type JUnitOperator struct {
+	logger logging.Logger
+}
+
+// NewJUnitOperator builds a JUnitOperator that logs read/parse failures
+// through logger.
+func NewJUnitOperator(logger logging.Logger) JUnitOperator {
+	return JUnitOperator{logger: logger}
+}
+
+// GetFailedTests parses opts.JunitFile and returns one FailedTest per
+// failing testcase.
+func (j *JUnitOperator) GetFailedTests(opts *options.Options) []*FailedTest {
+	suites, ok := j.readJunitFile(opts.JunitFile)
+	if !ok {
+		return nil
+	}
+
+	var failed []*FailedTest
+	for _, suite := range suites.Suites {
+		for _, tc := range suite.Cases {
+			if tc.Failure == nil {
+				continue
+			}
+			failed = append(failed, &FailedTest{
+				Title:    tc.Name,
+				Package:  suite.Package,
+				Output:   tc.Failure.Text,
+				Duration: time.Duration(tc.Time * float64(time.Second)),
+			})
+		}
+	}
+	return failed
+}
+
+// GetAllOutcomes parses opts.JunitFile and returns, for every testcase,
+// whether it passed, keyed by FailedTest.Key() ("package/test"). It is
+// used to feed pkg/history, which needs the outcome of passing tests too
+// in order to tell a flaky test from a consistently failing one.
+func (j *JUnitOperator) GetAllOutcomes(opts *options.Options) map[string]bool {
+	suites, ok := j.readJunitFile(opts.JunitFile)
+	if !ok {
+		return nil
+	}
+
+	outcomes := map[string]bool{}
+	for _, suite := range suites.Suites {
+		for _, tc := range suite.Cases {
+			key := (FailedTest{Package: suite.Package, Title: tc.Name}).Key()
+			outcomes[key] = tc.Failure == nil
+		}
+	}
+	return outcomes
+}
+
+func (j *JUnitOperator) readJunitFile(path string) (junitTestSuites, bool) {
+	var suites junitTestSuites
+	if path == "" {
+		return suites, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		j.logger.Error("could not read junit file", logging.Fields{"path": path, "error": err.Error()})
+		return suites, false
+	}
+
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		j.logger.Error("could not parse junit file", logging.Fields{"path": path, "error": err.Error()})
+		return suites, false
+	}
+	return suites, true
+}
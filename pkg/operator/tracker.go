@@ -0,0 +1,25 @@
+package operator
+
+// IssueTracker is the bridge between gotestsum and a remote issue
+// tracker. Every backend (GitHub, GitLab, Gitea, Jira, ...) implements
+// this interface so that run() in main.go can compute new/solved issues
+// without knowing which tracker it is talking to.
+type IssueTracker interface {
+	// Authenticate prepares the underlying client from the configured
+	// credentials. It does not check that the credentials are valid; a
+	// bad token surfaces on the first request. It is called once before
+	// any other method.
+	Authenticate() error
+
+	// GetTestIssues returns the currently open issues that were filed by
+	// a previous run, one FailedTest per issue.
+	GetTestIssues() ([]*FailedTest, error)
+
+	// PostNewIssue files a new issue for a test that just started
+	// failing.
+	PostNewIssue(t *FailedTest) error
+
+	// CloseSolvedIssue closes the issue that was filed for a test that
+	// is no longer failing.
+	CloseSolvedIssue(t *FailedTest) error
+}
@@ -0,0 +1,108 @@
+// Package options defines the flag-backed configuration shared by the
+// gotestsum and operator packages.
+package options
+
+import "fmt"
+
+// Options holds every value parsed from the command line and environment.
+type Options struct {
+	Args []string
+
+	Debug      bool
+	Version    bool
+	NoColor    bool
+	RawCommand bool
+
+	Format    string
+	JsonFile  string
+	JunitFile string
+
+	NoSummary                    *NoSummaryValue
+	JunitTestSuiteNameFormat     *JunitFieldFormatValue
+	JunitTestCaseClassnameFormat *JunitFieldFormatValue
+
+	Token     string
+	Owner     string
+	Repo      string
+	Post      bool
+	GitUnAuth bool
+
+	Tracker          string
+	TrackerURL       string
+	CredentialSource string
+	CredentialFile   string
+
+	Branch   string
+	SHA      string
+	PR       string
+	BuildURL string
+	CIName   string
+	Slug     string
+
+	PolicyDir string
+
+	ReportFormat []string
+	ReportDir    string
+
+	HistoryFile      string
+	HistoryRemoteURL string
+	HistoryWindow    int
+	FlakyThreshold   float64
+	QuarantineFile   string
+
+	LogBackend string
+	LogFormat  string
+}
+
+// JunitFieldFormatValues lists the accepted values for the
+// junitfile-testsuite-name and junitfile-testcase-classname flags.
+const JunitFieldFormatValues = "full, relative, short"
+
+// JunitFieldFormatValue is a pflag.Value that only accepts the values
+// listed in JunitFieldFormatValues.
+type JunitFieldFormatValue struct {
+	value string
+}
+
+func (v *JunitFieldFormatValue) String() string {
+	return v.value
+}
+
+func (v *JunitFieldFormatValue) Set(value string) error {
+	switch value {
+	case "full", "relative", "short", "":
+		v.value = value
+		return nil
+	default:
+		return fmt.Errorf("invalid value %q, expected one of %s", value, JunitFieldFormatValues)
+	}
+}
+
+func (v *JunitFieldFormatValue) Type() string {
+	return "string"
+}
+
+// NoSummaryValue is a pflag.Value that accumulates the set of summary
+// sections to omit from the output.
+type NoSummaryValue struct {
+	value []string
+}
+
+// NewNoSummaryValue returns an empty NoSummaryValue ready to be bound to a
+// flag.
+func NewNoSummaryValue() *NoSummaryValue {
+	return &NoSummaryValue{}
+}
+
+func (v *NoSummaryValue) String() string {
+	return fmt.Sprintf("%v", v.value)
+}
+
+func (v *NoSummaryValue) Set(value string) error {
+	v.value = append(v.value, value)
+	return nil
+}
+
+func (v *NoSummaryValue) Type() string {
+	return "stringSlice"
+}
@@ -0,0 +1,131 @@
+// Package policy lets a user control, via Rego, which failed tests turn
+// into issues and how those issues are labeled and what severity they
+// carry. It does not decide assignees or the closing of solved issues:
+// an assignees rule is ignored, and solved issues are closed whatever a
+// policy says. It is optional: when no policy directory is
+// configured the caller should skip this package entirely and keep the
+// existing "every failure becomes an issue" behavior.
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/istratem/gotestsum/pkg/logging"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// query is the Rego query every loaded policy is evaluated against. Each
+// policy is expected to live in the gotestsum.policy package and export
+// some subset of issue_should_open, labels and severity; any other rule
+// it exports is ignored.
+const query = "data.gotestsum.policy"
+
+// Engine evaluates one or more loaded Rego policies against a failed
+// test event.
+type Engine struct {
+	prepared rego.PreparedEvalQuery
+	logger   logging.Logger
+}
+
+// Test describes the failing test a policy decides on.
+type Test struct {
+	Name     string        `json:"name"`
+	Package  string        `json:"package"`
+	Output   string        `json:"output"`
+	Duration time.Duration `json:"duration"`
+}
+
+// CI describes the build the test failed in, enough for a policy to
+// decide things like "this _test.go file was touched by the current PR".
+type CI struct {
+	Branch       string   `json:"branch"`
+	PR           string   `json:"pr"`
+	ChangedFiles []string `json:"changed_files"`
+}
+
+// History describes the test's recent run history.
+type History struct {
+	ConsecutiveFailures int `json:"consecutive_failures"`
+}
+
+// Input is the document passed to every Rego policy as `input`.
+type Input struct {
+	Test    Test    `json:"test"`
+	CI      CI      `json:"ci"`
+	History History `json:"history"`
+}
+
+// Action is the set of decisions a policy can make about a failed test.
+type Action struct {
+	ShouldOpen bool     `json:"issue_should_open"`
+	Labels     []string `json:"labels"`
+	Severity   string   `json:"severity"`
+}
+
+// Load compiles every *.rego file under dir into an Engine.
+func Load(ctx context.Context, dir string, logger logging.Logger) (*Engine, error) {
+	r := rego.New(
+		rego.Query(query),
+		rego.Load([]string{dir}, nil),
+	)
+	engine, err := prepare(ctx, r, logger)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("policy loaded", logging.Fields{"dir": dir})
+	return engine, nil
+}
+
+// LoadModule compiles a single Rego module from source, without touching
+// the filesystem. It exists mainly so tests can exercise the engine with
+// synthetic policies.
+func LoadModule(ctx context.Context, name, source string, logger logging.Logger) (*Engine, error) {
+	r := rego.New(
+		rego.Query(query),
+		rego.Module(name, source),
+	)
+	return prepare(ctx, r, logger)
+}
+
+func prepare(ctx context.Context, r *rego.Rego, logger logging.Logger) (*Engine, error) {
+	prepared, err := r.PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("compiling policy: %w", err)
+	}
+	return &Engine{prepared: prepared, logger: logger}, nil
+}
+
+// Evaluate runs the loaded policies against input and returns the
+// resulting Action. A policy rule that is left undefined keeps its Rego
+// default (issue_should_open defaults to true, so a policy that defines
+// nothing at all is a no-op).
+func (e *Engine) Evaluate(ctx context.Context, input Input) (Action, error) {
+	results, err := e.prepared.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return Action{}, fmt.Errorf("evaluating policy: %w", err)
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return Action{ShouldOpen: true}, nil
+	}
+
+	// Round-trip through JSON: Eval returns a generic
+	// map[string]interface{} and Action is just a typed view of it.
+	raw, err := json.Marshal(results[0].Expressions[0].Value)
+	if err != nil {
+		return Action{}, fmt.Errorf("marshaling policy result: %w", err)
+	}
+
+	action := Action{ShouldOpen: true}
+	if err := json.Unmarshal(raw, &action); err != nil {
+		return Action{}, fmt.Errorf("unmarshaling policy result: %w", err)
+	}
+	e.logger.Debug("policy evaluated", logging.Fields{
+		"test":        input.Test.Name,
+		"should_open": action.ShouldOpen,
+		"severity":    action.Severity,
+	})
+	return action, nil
+}
@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/istratem/gotestsum/pkg/logging"
+)
+
+const testPolicy = `
+package gotestsum.policy
+
+import future.keywords.in
+
+default issue_should_open := true
+
+flaky_patterns := {"TestFlaky"}
+
+issue_should_open := false {
+	some pattern in flaky_patterns
+	contains(input.test.name, pattern)
+}
+
+issue_should_open := false {
+	input.history.consecutive_failures < 2
+}
+
+labels[label] {
+	some file in input.ci.changed_files
+	endswith(file, "_test.go")
+	label := "regression"
+}
+`
+
+func mustLoad(t *testing.T) *Engine {
+	t.Helper()
+	engine, err := LoadModule(context.Background(), "test.rego", testPolicy, logging.NewNopLogger())
+	if err != nil {
+		t.Fatalf("LoadModule: %v", err)
+	}
+	return engine
+}
+
+func TestEvaluate_DefaultOpensIssue(t *testing.T) {
+	engine := mustLoad(t)
+
+	action, err := engine.Evaluate(context.Background(), Input{
+		Test:    Test{Name: "TestSomethingBroke"},
+		History: History{ConsecutiveFailures: 3},
+	})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if !action.ShouldOpen {
+		t.Fatal("expected issue_should_open to be true")
+	}
+}
+
+func TestEvaluate_SkipsKnownFlaky(t *testing.T) {
+	engine := mustLoad(t)
+
+	action, err := engine.Evaluate(context.Background(), Input{
+		Test:    Test{Name: "TestFlakyUploader"},
+		History: History{ConsecutiveFailures: 10},
+	})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if action.ShouldOpen {
+		t.Fatal("expected issue_should_open to be false for a known-flaky test")
+	}
+}
+
+func TestEvaluate_SuppressesBelowConsecutiveFailureThreshold(t *testing.T) {
+	engine := mustLoad(t)
+
+	action, err := engine.Evaluate(context.Background(), Input{
+		Test:    Test{Name: "TestSomethingBroke"},
+		History: History{ConsecutiveFailures: 1},
+	})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if action.ShouldOpen {
+		t.Fatal("expected issue_should_open to be false below the consecutive-failure threshold")
+	}
+}
+
+func TestEvaluate_LabelsRegressionFromChangedTestFiles(t *testing.T) {
+	engine := mustLoad(t)
+
+	action, err := engine.Evaluate(context.Background(), Input{
+		Test:    Test{Name: "TestSomethingBroke"},
+		History: History{ConsecutiveFailures: 3},
+		CI:      CI{ChangedFiles: []string{"pkg/foo/foo_test.go"}},
+	})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+
+	found := false
+	for _, label := range action.Labels {
+		if label == "regression" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected labels to contain %q, got %v", "regression", action.Labels)
+	}
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"encoding/csv"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/istratem/gotestsum/pkg/operator"
+)
+
+var csvHeader = []string{"status", "name", "package", "first-seen", "last-seen", "issue-url", "labels"}
+
+type csvReporter struct{}
+
+func (csvReporter) Name() string { return "csv" }
+
+func (csvReporter) Write(path string, r Report) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+
+	write := func(status string, tests []operator.FailedTest) error {
+		for _, t := range tests {
+			if err := w.Write(csvRow(status, t)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := write("new", r.NewIssues); err != nil {
+		return err
+	}
+	if err := write("solved", r.SolvedIssues); err != nil {
+		return err
+	}
+	if err := write("failing", r.FailedTests); err != nil {
+		return err
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func csvRow(status string, t operator.FailedTest) []string {
+	return []string{
+		status,
+		t.Title,
+		t.Package,
+		formatTime(t.FirstSeen),
+		formatTime(t.LastSeen),
+		t.IssueURL,
+		strings.Join(t.Labels, ";"),
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/istratem/gotestsum/pkg/operator"
+)
+
+type htmlReporter struct{}
+
+func (htmlReporter) Name() string { return "html" }
+
+func (htmlReporter) Write(path string, r Report) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\">")
+	b.WriteString("<title>gotestsum report</title>")
+	b.WriteString("<style>body{font-family:monospace}details{margin:4px 0}summary{cursor:pointer}pre{white-space:pre-wrap}</style>")
+	b.WriteString("</head><body>\n")
+
+	writeSection(&b, "New issues", r.NewIssues)
+	writeSection(&b, "Solved issues", r.SolvedIssues)
+	writeSection(&b, "Currently failing", r.FailedTests)
+
+	b.WriteString("</body></html>\n")
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+func writeSection(b *strings.Builder, title string, tests []operator.FailedTest) {
+	fmt.Fprintf(b, "<h2>%s (%d)</h2>\n", html.EscapeString(title), len(tests))
+	for _, t := range tests {
+		fmt.Fprintf(b, "<details><summary>%s &mdash; %s</summary>\n", html.EscapeString(t.Package), html.EscapeString(t.Title))
+		if t.IssueURL != "" {
+			fmt.Fprintf(b, "<p><a href=\"%s\">%s</a></p>\n", html.EscapeString(t.IssueURL), html.EscapeString(t.IssueURL))
+		}
+		fmt.Fprintf(b, "<pre>%s</pre>\n", html.EscapeString(t.Output))
+		b.WriteString("</details>\n")
+	}
+}
@@ -0,0 +1,18 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+)
+
+type jsonReporter struct{}
+
+func (jsonReporter) Name() string { return "json" }
+
+func (jsonReporter) Write(path string, r Report) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,59 @@
+// Package report emits the outcome of a gotestsum run (the tests that
+// newly failed, the ones that got fixed, and the full set of current
+// failures) in a handful of machine-readable formats, so results can
+// flow into spreadsheets, BI tools, and code-scanning UIs.
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/istratem/gotestsum/pkg/operator"
+)
+
+// Report is the data every Reporter renders.
+type Report struct {
+	NewIssues    []operator.FailedTest
+	SolvedIssues []operator.FailedTest
+	FailedTests  []operator.FailedTest
+}
+
+// Reporter renders a Report in one output format.
+type Reporter interface {
+	// Name is the format name, also used as the file extension of the
+	// emitted report (e.g. "json" writes "report.json").
+	Name() string
+	Write(path string, r Report) error
+}
+
+// reporters maps a --report-format value to its Reporter.
+var reporters = map[string]Reporter{
+	"json":  jsonReporter{},
+	"csv":   csvReporter{},
+	"html":  htmlReporter{},
+	"sarif": sarifReporter{},
+}
+
+// EmitAll writes r through every requested format into dir, one file per
+// format named "report.<format>". dir is created if it doesn't exist.
+func EmitAll(dir string, formats []string, r Report) error {
+	if len(formats) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating report dir: %w", err)
+	}
+
+	for _, format := range formats {
+		reporter, ok := reporters[format]
+		if !ok {
+			return fmt.Errorf("unknown report format %q", format)
+		}
+		path := filepath.Join(dir, "report."+reporter.Name())
+		if err := reporter.Write(path, r); err != nil {
+			return fmt.Errorf("writing %s report: %w", format, err)
+		}
+	}
+	return nil
+}
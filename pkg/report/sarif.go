@@ -0,0 +1,109 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/istratem/gotestsum/pkg/operator"
+)
+
+// sarifReporter emits a minimal SARIF 2.1.0 log so failures show up in
+// GitHub's code-scanning UI. It only reports r.FailedTests: new/solved
+// issues are a gotestsum-specific concept that SARIF has no room for.
+type sarifReporter struct{}
+
+func (sarifReporter) Name() string { return "sarif" }
+
+// sarifLog and friends model only the subset of the SARIF 2.1.0 schema
+// gotestsum needs; see https://docs.oasis-open.org/sarif/sarif/v2.1.0.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func (sarifReporter) Write(path string, r Report) error {
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:  "gotestsum",
+				Rules: sarifRules(r.FailedTests),
+			}},
+			Results: sarifResults(r.FailedTests),
+		}},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func sarifRules(tests []operator.FailedTest) []sarifRule {
+	rules := make([]sarifRule, len(tests))
+	for i, t := range tests {
+		rules[i] = sarifRule{ID: t.Title}
+	}
+	return rules
+}
+
+func sarifResults(tests []operator.FailedTest) []sarifResult {
+	results := make([]sarifResult, len(tests))
+	for i, t := range tests {
+		results[i] = sarifResult{
+			RuleID:  t.Title,
+			Level:   "error",
+			Message: sarifMessage{Text: t.Output},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: t.Package},
+				},
+			}},
+		}
+	}
+	return results
+}